@@ -0,0 +1,81 @@
+package telelogger
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormatDuration formats a duration as a short human-readable string.
+// Durations under a minute are shown with up to two decimals in the largest
+// fitting unit, longer ones are rounded to the second.
+//
+// Example:
+//
+//	telelogger.FormatDuration(1234567890 * time.Nanosecond) // "1.23s"
+//	telelogger.FormatDuration(1500 * time.Microsecond)      // "1.5ms"
+//	telelogger.FormatDuration(90 * time.Second)             // "1m30s"
+func FormatDuration(d time.Duration) string {
+	if d < 0 {
+		return "-" + FormatDuration(d.Abs())
+	}
+
+	if d < time.Microsecond {
+		return fmt.Sprintf("%dns", int64(d))
+	}
+
+	// Move up a unit when rounding reaches the next unit's size
+	units := []struct {
+		size   time.Duration
+		limit  float64
+		suffix string
+	}{
+		{time.Microsecond, 1000, "µs"},
+		{time.Millisecond, 1000, "ms"},
+		{time.Second, 60, "s"},
+	}
+	for _, u := range units {
+		v := math.Round(float64(d)/float64(u.size)*100) / 100
+		if v < u.limit {
+			return formatDecimal(v) + u.suffix
+		}
+	}
+	return d.Round(time.Second).String()
+}
+
+// FormatBytes formats a byte count as a human-readable string using binary units.
+//
+// Example:
+//
+//	telelogger.FormatBytes(512)     // "512 B"
+//	telelogger.FormatBytes(1048576) // "1.0 MiB"
+func FormatBytes(n int64) string {
+	sign, size := "", uint64(n)
+	if n < 0 {
+		sign, size = "-", uint64(-n)
+	}
+
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%s%d B", sign, size)
+	}
+
+	// Move up a unit when rounding reaches the next unit's size
+	const units = "KMGTPE"
+	v := float64(size)
+	for i := 0; ; i++ {
+		v /= unit
+		if math.Round(v*10)/10 < unit || i == len(units)-1 {
+			return fmt.Sprintf("%s%.1f %ciB", sign, v, units[i])
+		}
+	}
+}
+
+// formatDecimal formats v with at most two decimals, dropping trailing zeros.
+func formatDecimal(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 2, 64)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
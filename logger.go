@@ -0,0 +1,98 @@
+package telelogger
+
+import (
+	"errors"
+	"runtime"
+)
+
+// Logger is the set of logging methods implemented by Telelogger.
+// It allows code to depend on logging behavior rather than on Telegram,
+// so that other implementations can be swapped in or combined with NewTee.
+type Logger interface {
+	Log(msg string) error
+	LogWithParseMode(msg string, parseMode ParseMode) error
+	LogError(err interface{}) error
+	LogInfo(msg string) error
+	LogSuccess(msg string) error
+	LogWarn(msg string) error
+}
+
+var _ Logger = (*Telelogger)(nil)
+
+// stackLogger is implemented by loggers that can log an error with a stack trace
+// captured by a wrapping logger, keeping the wrapper's frames out of the trace.
+type stackLogger interface {
+	logErrorWithStack(err interface{}, stack []runtime.Frame) error
+}
+
+// tee is a Logger that forwards every call to several loggers.
+type tee struct {
+	loggers []Logger
+}
+
+// NewTee creates a Logger that forwards every call to all of the given loggers,
+// in order. Every logger is called even if an earlier one fails; the errors of
+// all failing loggers are joined into the returned error.
+//
+// Example:
+//
+//	logger := telelogger.NewTee(
+//	    telelogger.New(telelogger.Config{BotToken: oldToken, ChatID: oldChatID}),
+//	    telelogger.New(telelogger.Config{BotToken: newToken, ChatID: newChatID}),
+//	)
+func NewTee(loggers ...Logger) Logger {
+	return &tee{loggers: loggers}
+}
+
+// each calls fn for every underlying logger and joins the errors.
+func (t *tee) each(fn func(Logger) error) error {
+	var errs []error
+	for _, logger := range t.loggers {
+		if err := fn(logger); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Log sends a generic message to all loggers.
+func (t *tee) Log(msg string) error {
+	return t.each(func(l Logger) error { return l.Log(msg) })
+}
+
+// LogWithParseMode sends a generic message with a specific parse mode to all loggers.
+func (t *tee) LogWithParseMode(msg string, parseMode ParseMode) error {
+	return t.each(func(l Logger) error { return l.LogWithParseMode(msg, parseMode) })
+}
+
+// LogError sends an error message to all loggers.
+// Loggers that capture stack traces get the stack of the caller of LogError.
+func (t *tee) LogError(err interface{}) error {
+	return t.logErrorWithStack(err, captureStack(0))
+}
+
+// logErrorWithStack sends an error message to all loggers, passing stack on to
+// those that capture stack traces.
+func (t *tee) logErrorWithStack(err interface{}, stack []runtime.Frame) error {
+	return t.each(func(l Logger) error {
+		if sl, ok := l.(stackLogger); ok {
+			return sl.logErrorWithStack(err, stack)
+		}
+		return l.LogError(err)
+	})
+}
+
+// LogInfo sends an info message to all loggers.
+func (t *tee) LogInfo(msg string) error {
+	return t.each(func(l Logger) error { return l.LogInfo(msg) })
+}
+
+// LogSuccess sends a success message to all loggers.
+func (t *tee) LogSuccess(msg string) error {
+	return t.each(func(l Logger) error { return l.LogSuccess(msg) })
+}
+
+// LogWarn sends a warning message to all loggers.
+func (t *tee) LogWarn(msg string) error {
+	return t.each(func(l Logger) error { return l.LogWarn(msg) })
+}
@@ -6,9 +6,22 @@ package telelogger
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"html"
+	"io"
+	"net"
 	"net/http"
+	"reflect"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 )
 
 // Version represents the current version of the package
@@ -27,6 +40,24 @@ const (
 	ParseModeMarkdownV2 ParseMode = "MarkdownV2"
 )
 
+// DedupStrategy controls how repeated identical error messages are handled.
+type DedupStrategy string
+
+const (
+	// DedupStrategyNone sends every error message as a new message
+	DedupStrategyNone DedupStrategy = ""
+	// DedupStrategyEdit sends the first occurrence of an error and edits that
+	// message with a live count for identical errors within the dedup window
+	DedupStrategyEdit DedupStrategy = "edit"
+)
+
+// defaultDedupWindow is used when a dedup strategy is set without a window
+const defaultDedupWindow = time.Minute
+
+// ErrBlocked is returned when a message matches one of the configured
+// BlockPatterns and is therefore not sent to Telegram.
+var ErrBlocked = errors.New("message blocked by content filter")
+
 // FormatterFunc is a function type for message formatting.
 // It takes a message string and returns a formatted string.
 type FormatterFunc func(message string) string
@@ -65,6 +96,52 @@ type Config struct {
 	// WarnFormatter is a custom formatter for warning messages
 	// If not provided, uses default format with 🚨 emoji
 	WarnFormatter FormatterFunc
+
+	// BlockPatterns is a list of patterns that messages must never contain.
+	// A message matching any of them is dropped and ErrBlocked is returned.
+	// If not provided, no messages are blocked
+	BlockPatterns []*regexp.Regexp
+
+	// FallbackWriter receives the text of blocked messages instead of Telegram
+	// If not provided, blocked messages are discarded
+	FallbackWriter io.Writer
+
+	// HTTPClient is the HTTP client used to call the Telegram API.
+	// A single client may be shared by any number of loggers, which then reuse
+	// its connection pool; it is safe for concurrent use.
+	// If provided, DialTimeout and Resolver are ignored
+	// If not provided, a new client is created for this logger
+	HTTPClient *http.Client
+
+	// DialTimeout limits how long establishing a connection to the Telegram API,
+	// including DNS resolution, may take
+	// If not provided, the default transport's dial timeout is used
+	DialTimeout time.Duration
+
+	// Resolver is a custom DNS resolver used to look up the Telegram API host
+	// If not provided, the default resolver is used
+	Resolver *net.Resolver
+
+	// CaptureStackOnError appends the current stack trace to messages sent with
+	// LogError when the logged value does not carry a stack trace of its own
+	// If not provided, no stack trace is captured
+	CaptureStackOnError bool
+
+	// StackSkip is the number of additional stack frames to skip when capturing
+	// a stack trace, useful when LogError is called through a helper function
+	// If not provided or negative, the trace starts at the caller of LogError
+	StackSkip int
+
+	// DedupStrategy controls how identical error messages logged in quick
+	// succession are handled
+	// Can be DedupStrategyNone or DedupStrategyEdit
+	// If not provided, every error is sent as a new message
+	DedupStrategy DedupStrategy
+
+	// DedupWindow is how long after its last occurrence an error is still
+	// considered a repeat
+	// If not provided, defaults to one minute
+	DedupWindow time.Duration
 }
 
 // Telelogger is the main struct for sending formatted log messages to Telegram.
@@ -78,7 +155,26 @@ type Telelogger struct {
 	errorFormatter   FormatterFunc
 	successFormatter FormatterFunc
 	warnFormatter    FormatterFunc
+	blockPatterns    []*regexp.Regexp
+	fallbackWriter   io.Writer
+	captureStack     bool
+	stackSkip        int
+	dedupStrategy    DedupStrategy
+	dedupWindow      time.Duration
 	client           *http.Client
+
+	mu    sync.Mutex
+	dedup map[string]*dedupEntry
+}
+
+// dedupEntry tracks a sent error message that repeats of the same error update
+type dedupEntry struct {
+	messageID int
+	text      string
+	count     int
+	lastSeen  time.Time
+	editing   bool
+	nextEdit  time.Time
 }
 
 // message represents the structure of a Telegram message for API requests
@@ -88,6 +184,99 @@ type message struct {
 	ParseMode ParseMode `json:"parse_mode,omitempty"`
 }
 
+// pinRequest represents the structure of a pinChatMessage API request
+type pinRequest struct {
+	ChatID              int64 `json:"chat_id"`
+	MessageID           int   `json:"message_id"`
+	DisableNotification bool  `json:"disable_notification"`
+}
+
+// deleteMessageRequest represents the structure of a deleteMessage API request
+type deleteMessageRequest struct {
+	ChatID    int64 `json:"chat_id"`
+	MessageID int   `json:"message_id"`
+}
+
+// deleteMessagesRequest represents the structure of a deleteMessages API request
+type deleteMessagesRequest struct {
+	ChatID     int64 `json:"chat_id"`
+	MessageIDs []int `json:"message_ids"`
+}
+
+// maxMessageLength is the maximum length of a Telegram message text
+const maxMessageLength = 4096
+
+// maxStackDepth is the maximum number of frames captured for a stack trace
+const maxStackDepth = 32
+
+// maxDeleteBatch is the maximum number of messages deleteMessages accepts per request
+const maxDeleteBatch = 100
+
+// maxDrainBytes caps how much of an unread response body is discarded before closing it
+const maxDrainBytes = 64 << 10
+
+// maxRateLimitRetries is how many times a rate-limited request is retried
+const maxRateLimitRetries = 3
+
+// editMessageRequest represents the structure of an editMessageText API request
+type editMessageRequest struct {
+	ChatID    int64     `json:"chat_id"`
+	MessageID int       `json:"message_id"`
+	Text      string    `json:"text"`
+	ParseMode ParseMode `json:"parse_mode,omitempty"`
+}
+
+// dedupEditInterval is the minimum time between two edits of a deduplicated message
+const dedupEditInterval = time.Second
+
+// dedupSuffixReserve is the room left in error messages for the dedup count line
+const dedupSuffixReserve = 64
+
+// sentMessage holds the fields we read back from a sent Telegram message
+type sentMessage struct {
+	MessageID int `json:"message_id"`
+}
+
+// apiResponse represents the envelope of every Telegram Bot API response
+type apiResponse struct {
+	OK          bool            `json:"ok"`
+	Result      json.RawMessage `json:"result"`
+	ErrorCode   int             `json:"error_code"`
+	Description string          `json:"description"`
+	Parameters  struct {
+		RetryAfter int `json:"retry_after"`
+	} `json:"parameters"`
+}
+
+// APIError is returned when the Telegram Bot API rejects a request.
+// Description carries Telegram's explanation, e.g. "Bad Request: not enough
+// rights to manage pinned messages in the chat".
+type APIError struct {
+	// Method is the Bot API method that failed, e.g. "pinChatMessage"
+	Method string
+
+	// StatusCode is the HTTP status code returned by the API
+	StatusCode int
+
+	// Description is the error description returned by the API, if any
+	Description string
+
+	// RetryAfter is the number of seconds to wait before retrying,
+	// set when the request was rate limited
+	RetryAfter int
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("telegram API rejected %s", e.Method)
+	if e.StatusCode != http.StatusOK {
+		msg = fmt.Sprintf("telegram API returned non-200 status code for %s: %d", e.Method, e.StatusCode)
+	}
+	if e.Description != "" {
+		msg += ": " + e.Description
+	}
+	return msg
+}
+
 // New creates a new Telelogger instance with the provided configuration.
 //
 // Example:
@@ -106,7 +295,21 @@ func New(config Config) *Telelogger {
 		errorFormatter:   config.ErrorFormatter,
 		successFormatter: config.SuccessFormatter,
 		warnFormatter:    config.WarnFormatter,
-		client:           &http.Client{},
+		blockPatterns:    config.BlockPatterns,
+		fallbackWriter:   config.FallbackWriter,
+		captureStack:     config.CaptureStackOnError,
+		stackSkip:        config.StackSkip,
+		dedupStrategy:    config.DedupStrategy,
+		dedupWindow:      config.DedupWindow,
+		client:           newHTTPClient(config),
+		dedup:            make(map[string]*dedupEntry),
+	}
+
+	if t.dedupWindow <= 0 {
+		t.dedupWindow = defaultDedupWindow
+	}
+	if t.stackSkip < 0 {
+		t.stackSkip = 0
 	}
 
 	// Set default formatters if not provided
@@ -126,13 +329,48 @@ func New(config Config) *Telelogger {
 	return t
 }
 
+// newHTTPClient returns the HTTP client used to reach the Telegram API.
+// Unless a client is provided, it builds one, wiring the configured dial timeout
+// and resolver into the transport's dialer.
+func newHTTPClient(config Config) *http.Client {
+	if config.HTTPClient != nil {
+		return config.HTTPClient
+	}
+	if config.DialTimeout == 0 && config.Resolver == nil {
+		return &http.Client{}
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Resolver:  config.Resolver,
+	}
+	if config.DialTimeout > 0 {
+		dialer.Timeout = config.DialTimeout
+	}
+
+	// Mirror the settings of net/http's default transport, which may have been
+	// replaced by the program and so cannot be cloned reliably
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	return &http.Client{Transport: transport}
+}
+
 // Log sends a generic message to Telegram.
 //
 // Example:
 //
 //	err := logger.Log("Generic message")
 func (t *Telelogger) Log(msg string) error {
-	return t.sendMessage(msg, t.parseMode)
+	return t.send(msg, t.parseMode)
 }
 
 // LogWithParseMode sends a generic message to Telegram with a specific parse mode.
@@ -141,11 +379,17 @@ func (t *Telelogger) Log(msg string) error {
 //
 //	err := logger.LogWithParseMode("Message with <b>bold</b> text", telelogger.ParseModeHTML)
 func (t *Telelogger) LogWithParseMode(msg string, parseMode ParseMode) error {
-	return t.sendMessage(msg, parseMode)
+	return t.send(msg, parseMode)
 }
 
 // LogError sends an error message to Telegram.
 // The error parameter can be either an error object or a string.
+// If CaptureStackOnError is enabled, the current stack trace is appended to the
+// message, trimmed to fit Telegram's message length limit.
+// If DedupStrategy is DedupStrategyEdit, repeats of a recent error update the
+// message sent for its first occurrence instead of sending a new one. These
+// edits happen in the background, at most once per second, so errors from
+// them are not returned.
 //
 // Example:
 //
@@ -153,6 +397,24 @@ func (t *Telelogger) LogWithParseMode(msg string, parseMode ParseMode) error {
 //	// or
 //	err := logger.LogError(fmt.Errorf("Database connection failed"))
 func (t *Telelogger) LogError(err interface{}) error {
+	var stack []runtime.Frame
+	if t.captureStack && !hasStackTrace(err) {
+		stack = captureStack(t.stackSkip)
+	}
+	return t.logError(err, stack)
+}
+
+// logErrorWithStack logs an error with a stack trace captured by a wrapping
+// logger such as NewTee, so that the trace starts at the wrapper's caller.
+func (t *Telelogger) logErrorWithStack(err interface{}, stack []runtime.Frame) error {
+	if !t.captureStack || hasStackTrace(err) {
+		return t.logError(err, nil)
+	}
+	return t.logError(err, stack[min(t.stackSkip, len(stack)):])
+}
+
+// logError formats and sends an error message, appending stack if it is non-empty.
+func (t *Telelogger) logError(err interface{}, stack []runtime.Frame) error {
 	var msg string
 	switch v := err.(type) {
 	case error:
@@ -162,7 +424,229 @@ func (t *Telelogger) LogError(err interface{}) error {
 	default:
 		msg = fmt.Sprintf("%v", v)
 	}
-	return t.sendMessage(t.errorFormatter(msg), t.parseMode)
+	key := msg
+
+	dedup := t.dedupStrategy == DedupStrategyEdit
+	var text string
+	if dedup || len(stack) > 0 {
+		allowed := maxMessageLength
+		if dedup {
+			allowed -= dedupSuffixReserve
+		}
+		// Format once to learn how much room the error formatter leaves for the message
+		limit := allowed - (len(t.errorFormatter(msg)) - len(msg))
+		var size int
+		text, size = t.fitError(msg, stack, limit, dedup)
+		for len(text) > allowed && size > 0 {
+			// The formatter grows its input, e.g. by escaping it; shrink in proportion until it fits
+			text, size = t.fitError(msg, stack, size*allowed/len(text)-1, true)
+		}
+	} else {
+		text = t.errorFormatter(msg)
+	}
+
+	if dedup {
+		return t.sendDeduped(context.Background(), key, text)
+	}
+	return t.send(text, t.parseMode)
+}
+
+// fitError formats an error message with as much of stack as fits in limit bytes
+// before formatting, truncating msg itself first if shorten is set. It returns
+// the formatted message and its length before formatting.
+func (t *Telelogger) fitError(msg string, stack []runtime.Frame, limit int, shorten bool) (string, int) {
+	if shorten {
+		msg = truncate(msg, limit)
+	}
+	if len(stack) > 0 {
+		msg = t.withStackTrace(msg, stack, limit)
+	}
+	return t.errorFormatter(msg), len(msg)
+}
+
+// sendDeduped sends text for the error identified by key, or, if the same error
+// was seen within the dedup window, records the repeat and schedules an edit of
+// the earlier message showing how often and when it was last seen. The lock is
+// only held to update the dedup state, never during API calls.
+func (t *Telelogger) sendDeduped(ctx context.Context, key, text string) error {
+	t.mu.Lock()
+	now := time.Now()
+	for k, entry := range t.dedup {
+		if now.Sub(entry.lastSeen) > t.dedupWindow {
+			delete(t.dedup, k)
+		}
+	}
+
+	entry, ok := t.dedup[key]
+	if ok {
+		entry.count++
+		entry.lastSeen = now
+		// Edits are scheduled once the first message is sent, and an edit in
+		// flight picks up the new count, so only start one if neither applies
+		if entry.messageID != 0 && !entry.editing {
+			entry.editing = true
+			go t.flushEdits(key, entry)
+		}
+		t.mu.Unlock()
+		return nil
+	}
+
+	// Reserve the entry so repeats arriving during the send don't send again
+	entry = &dedupEntry{text: text, count: 1, lastSeen: now}
+	t.dedup[key] = entry
+	t.mu.Unlock()
+
+	messageID, err := t.sendMessage(ctx, text, t.parseMode)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err != nil {
+		if t.dedup[key] == entry {
+			delete(t.dedup, key)
+		}
+		return err
+	}
+	entry.messageID = messageID
+	if entry.count > 1 {
+		// Repeats arrived while the message was being sent
+		entry.editing = true
+		go t.flushEdits(key, entry)
+	}
+	return nil
+}
+
+// flushEdits edits the message of entry until it shows the latest count,
+// at most once per dedupEditInterval. Only one flushEdits runs per entry.
+func (t *Telelogger) flushEdits(key string, entry *dedupEntry) {
+	for {
+		t.mu.Lock()
+		wait := time.Until(entry.nextEdit)
+		t.mu.Unlock()
+		if wait > 0 {
+			time.Sleep(wait)
+		}
+
+		t.mu.Lock()
+		count := entry.count
+		edit := editMessageRequest{
+			ChatID:    t.chatID,
+			MessageID: entry.messageID,
+			Text:      fmt.Sprintf("%s\n\n🔁 seen %d× — last %s", entry.text, count, entry.lastSeen.Format("15:04:05")),
+			ParseMode: t.parseMode,
+		}
+		t.mu.Unlock()
+
+		err := t.call(context.Background(), "editMessageText", edit, nil)
+
+		t.mu.Lock()
+		entry.nextEdit = time.Now().Add(dedupEditInterval)
+		var apiErr *APIError
+		switch {
+		case errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests:
+			// Retry with the latest count once Telegram allows it
+			entry.nextEdit = time.Now().Add(max(time.Duration(apiErr.RetryAfter)*time.Second, dedupEditInterval))
+			t.mu.Unlock()
+			continue
+		case apiErr != nil:
+			// Telegram refused the edit (e.g. the message was deleted), so the
+			// next repeat starts over with a new message
+			if t.dedup[key] == entry {
+				delete(t.dedup, key)
+			}
+		case err == nil && entry.count != count:
+			// More repeats arrived during the edit
+			t.mu.Unlock()
+			continue
+		}
+		// Done, or a network error that the next repeat will retry
+		entry.editing = false
+		t.mu.Unlock()
+		return
+	}
+}
+
+// truncate shortens s to at most n bytes, cutting at a character boundary
+// and marking the cut with an ellipsis.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	const ellipsis = "…"
+	cut := n - len(ellipsis)
+	if cut <= 0 {
+		return ""
+	}
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + ellipsis
+}
+
+// captureStack returns the frames of the current goroutine's stack, starting at
+// the caller of the function that calls captureStack plus skip frames.
+func captureStack(skip int) []runtime.Frame {
+	pcs := make([]uintptr, maxStackDepth)
+	// Skip runtime.Callers, captureStack and its caller
+	n := runtime.Callers(3+skip, pcs)
+
+	var stack []runtime.Frame
+	frames := runtime.CallersFrames(pcs[:n])
+	for {
+		frame, more := frames.Next()
+		stack = append(stack, frame)
+		if !more {
+			break
+		}
+	}
+	return stack
+}
+
+// hasStackTrace reports whether err, or any error it wraps, exposes its own
+// stack trace through a StackTrace method, as github.com/pkg/errors does.
+func hasStackTrace(err interface{}) bool {
+	e, ok := err.(error)
+	for ok && e != nil {
+		if reflect.ValueOf(e).MethodByName("StackTrace").IsValid() {
+			return true
+		}
+		e = errors.Unwrap(e)
+	}
+	return false
+}
+
+// withStackTrace appends the stack frames to msg as a code block suited to the
+// logger's parse mode, dropping the outermost frames that would make the result
+// longer than limit.
+func (t *Telelogger) withStackTrace(msg string, stack []runtime.Frame, limit int) string {
+	var trace strings.Builder
+	for _, frame := range stack {
+		entry := fmt.Sprintf("%s\n\t%s:%d\n", frame.Function, frame.File, frame.Line)
+		candidate := strings.TrimSuffix(trace.String()+entry, "\n")
+		if len(t.codeBlock(msg, candidate)) > limit {
+			break
+		}
+		trace.WriteString(entry)
+	}
+	if trace.Len() == 0 {
+		return msg
+	}
+
+	return t.codeBlock(msg, strings.TrimSuffix(trace.String(), "\n"))
+}
+
+// codeBlock appends code to msg as a preformatted block for the logger's parse mode.
+func (t *Telelogger) codeBlock(msg, code string) string {
+	switch t.parseMode {
+	case ParseModeHTML:
+		return fmt.Sprintf("%s\n\n<pre>%s</pre>", msg, html.EscapeString(code))
+	case ParseModeMarkdownV2:
+		code = strings.NewReplacer("\\", "\\\\", "`", "\\`").Replace(code)
+		return fmt.Sprintf("%s\n\n```\n%s\n```", msg, code)
+	case ParseModeMarkdown:
+		return fmt.Sprintf("%s\n\n```\n%s\n```", msg, code)
+	default:
+		return fmt.Sprintf("%s\n\n%s", msg, code)
+	}
 }
 
 // LogInfo sends an info message to Telegram.
@@ -171,7 +655,7 @@ func (t *Telelogger) LogError(err interface{}) error {
 //
 //	err := logger.LogInfo("Application started successfully")
 func (t *Telelogger) LogInfo(msg string) error {
-	return t.sendMessage(t.infoFormatter(msg), t.parseMode)
+	return t.send(t.infoFormatter(msg), t.parseMode)
 }
 
 // LogSuccess sends a success message to Telegram.
@@ -180,7 +664,7 @@ func (t *Telelogger) LogInfo(msg string) error {
 //
 //	err := logger.LogSuccess("Backup completed successfully")
 func (t *Telelogger) LogSuccess(msg string) error {
-	return t.sendMessage(t.successFormatter(msg), t.parseMode)
+	return t.send(t.successFormatter(msg), t.parseMode)
 }
 
 // LogWarn sends a warning message to Telegram.
@@ -189,35 +673,235 @@ func (t *Telelogger) LogSuccess(msg string) error {
 //
 //	err := logger.LogWarn("Low disk space")
 func (t *Telelogger) LogWarn(msg string) error {
-	return t.sendMessage(t.warnFormatter(msg), t.parseMode)
+	return t.send(t.warnFormatter(msg), t.parseMode)
+}
+
+// Announce sends a message to Telegram and pins it in the chat with a notification.
+// It returns the ID of the sent message. If the message was sent but could not be
+// pinned (for example because the bot lacks the rights to pin messages), the message
+// ID is still returned alongside an *APIError describing the failure.
+//
+// Example:
+//
+//	id, err := logger.Announce(ctx, "Maintenance starts at 22:00 UTC")
+func (t *Telelogger) Announce(ctx context.Context, msg string) (int, error) {
+	messageID, err := t.sendMessage(ctx, msg, t.parseMode)
+	if err != nil {
+		return 0, err
+	}
+
+	pin := pinRequest{
+		ChatID:    t.chatID,
+		MessageID: messageID,
+	}
+	if err := t.call(ctx, "pinChatMessage", pin, nil); err != nil {
+		return messageID, fmt.Errorf("failed to pin message %d: %w", messageID, err)
+	}
+
+	return messageID, nil
+}
+
+// DeleteMessagesError is returned by DeleteMessages when some messages could not be deleted.
+type DeleteMessagesError struct {
+	// Failed maps the ID of every message that could not be deleted to the reason
+	Failed map[int]error
+}
+
+func (e *DeleteMessagesError) Error() string {
+	ids := make([]int, 0, len(e.Failed))
+	for id := range e.Failed {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = fmt.Sprintf("%d: %v", id, e.Failed[id])
+	}
+	return fmt.Sprintf("failed to delete %d messages: %s", len(ids), strings.Join(parts, "; "))
+}
+
+// Unwrap returns the individual deletion errors.
+func (e *DeleteMessagesError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failed))
+	for _, err := range e.Failed {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// DeleteMessages deletes multiple messages from the chat.
+// Messages are deleted in bulk using the deleteMessages API method. If Telegram
+// rejects a bulk request, its messages are deleted one by one with deleteMessage
+// so that the failing IDs can be identified. Rate-limited requests are retried
+// after the delay requested by Telegram. If any message could not be deleted, a
+// *DeleteMessagesError listing the failed IDs is returned. If deletion stops
+// early because of a network failure or a cancelled context, every message that
+// was not processed is listed as failed with that error.
+//
+// Example:
+//
+//	err := logger.DeleteMessages(ctx, []int{101, 102, 103})
+func (t *Telelogger) DeleteMessages(ctx context.Context, messageIDs []int) error {
+	failed := make(map[int]error)
+
+	for start := 0; start < len(messageIDs); start += maxDeleteBatch {
+		batch := messageIDs[start:min(start+maxDeleteBatch, len(messageIDs))]
+
+		bulk := deleteMessagesRequest{
+			ChatID:     t.chatID,
+			MessageIDs: batch,
+		}
+		err := t.callWithRetry(ctx, "deleteMessages", bulk, nil)
+		if err == nil {
+			continue
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || (apiErr.StatusCode != http.StatusBadRequest && apiErr.StatusCode != http.StatusNotFound) {
+			return abortDelete(failed, messageIDs[start:], err)
+		}
+
+		for i, id := range batch {
+			single := deleteMessageRequest{
+				ChatID:    t.chatID,
+				MessageID: id,
+			}
+			err := t.callWithRetry(ctx, "deleteMessage", single, nil)
+			if err == nil {
+				continue
+			}
+			if !errors.As(err, &apiErr) {
+				return abortDelete(failed, messageIDs[start+i:], err)
+			}
+			failed[id] = err
+		}
+	}
+
+	if len(failed) > 0 {
+		return &DeleteMessagesError{Failed: failed}
+	}
+	return nil
+}
+
+// abortDelete records every unprocessed message as failed with err and returns
+// the resulting *DeleteMessagesError.
+func abortDelete(failed map[int]error, unprocessed []int, err error) error {
+	for _, id := range unprocessed {
+		failed[id] = err
+	}
+	return &DeleteMessagesError{Failed: failed}
+}
+
+// send delivers a message for the Log* methods, which only report errors.
+func (t *Telelogger) send(text string, parseMode ParseMode) error {
+	_, err := t.sendMessage(context.Background(), text, parseMode)
+	return err
 }
 
 // sendMessage handles the actual sending of messages to Telegram.
-// It formats the message according to the specified parse mode and sends it via the Telegram Bot API.
-func (t *Telelogger) sendMessage(text string, parseMode ParseMode) error {
+// It formats the message according to the specified parse mode, sends it via the
+// Telegram Bot API and returns the ID of the sent message.
+func (t *Telelogger) sendMessage(ctx context.Context, text string, parseMode ParseMode) (int, error) {
+	if err := t.checkBlocked(text); err != nil {
+		return 0, err
+	}
+
 	msg := message{
 		ChatID:    t.chatID,
 		Text:      text,
 		ParseMode: parseMode,
 	}
 
-	payload, err := json.Marshal(msg)
+	var sent sentMessage
+	if err := t.call(ctx, "sendMessage", msg, &sent); err != nil {
+		return 0, fmt.Errorf("failed to send message: %w", err)
+	}
+
+	return sent.MessageID, nil
+}
+
+// checkBlocked reports whether text matches any of the configured block patterns.
+// Blocked messages are written to the fallback writer, if one is configured.
+func (t *Telelogger) checkBlocked(text string) error {
+	for _, pattern := range t.blockPatterns {
+		if !pattern.MatchString(text) {
+			continue
+		}
+		if t.fallbackWriter != nil {
+			if _, err := fmt.Fprintln(t.fallbackWriter, text); err != nil {
+				return fmt.Errorf("%w (pattern %q); failed to write to fallback: %v", ErrBlocked, pattern.String(), err)
+			}
+		}
+		return fmt.Errorf("%w (pattern %q)", ErrBlocked, pattern.String())
+	}
+	return nil
+}
+
+// callWithRetry invokes a Telegram Bot API method like call, waiting and retrying
+// when the API responds that the request was rate limited.
+func (t *Telelogger) callWithRetry(ctx context.Context, method string, payload interface{}, result interface{}) error {
+	for attempt := 0; ; attempt++ {
+		err := t.call(ctx, method, payload, result)
+
+		var apiErr *APIError
+		if attempt >= maxRateLimitRetries || !errors.As(err, &apiErr) ||
+			apiErr.StatusCode != http.StatusTooManyRequests || apiErr.RetryAfter <= 0 {
+			return err
+		}
+
+		timer := time.NewTimer(time.Duration(apiErr.RetryAfter) * time.Second)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// call invokes a Telegram Bot API method with a JSON payload.
+// If result is non-nil, the "result" field of the response is decoded into it.
+func (t *Telelogger) call(ctx context.Context, method string, payload interface{}, result interface{}) error {
+	body, err := json.Marshal(payload)
 	if err != nil {
-		return fmt.Errorf("failed to marshal message: %w", err)
+		return fmt.Errorf("failed to marshal %s request: %w", method, err)
 	}
 
-	resp, err := t.client.Post(
-		fmt.Sprintf("%s/sendMessage", t.baseURL),
-		"application/json",
-		bytes.NewBuffer(payload),
-	)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/%s", t.baseURL, method), bytes.NewBuffer(body))
 	if err != nil {
-		return fmt.Errorf("failed to send message: %w", err)
+		return fmt.Errorf("failed to create %s request: %w", method, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call %s: %w", method, err)
+	}
+	defer func() {
+		// Drain what is left of a normal-sized body so the connection can be reused
+		io.CopyN(io.Discard, resp.Body, maxDrainBytes)
+		resp.Body.Close()
+	}()
+
+	var apiResp apiResponse
+	decodeErr := json.NewDecoder(resp.Body).Decode(&apiResp)
+
+	if resp.StatusCode != http.StatusOK || (decodeErr == nil && !apiResp.OK) {
+		return &APIError{
+			Method:      method,
+			StatusCode:  resp.StatusCode,
+			Description: apiResp.Description,
+			RetryAfter:  apiResp.Parameters.RetryAfter,
+		}
+	}
+	if decodeErr != nil {
+		return fmt.Errorf("failed to decode %s response: %w", method, decodeErr)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("telegram API returned non-200 status code: %d", resp.StatusCode)
+	if result != nil {
+		if err := json.Unmarshal(apiResp.Result, result); err != nil {
+			return fmt.Errorf("failed to decode %s result: %w", method, err)
+		}
 	}
 
 	return nil
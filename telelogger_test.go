@@ -1,11 +1,25 @@
 package telelogger_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"html"
+	"io"
 	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"os"
+	"regexp"
 	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/monkhai/telelogger-golang"
@@ -40,6 +54,40 @@ func TestMain(m *testing.M) {
 	os.Exit(m.Run())
 }
 
+// redirectTransport sends every request to a test server instead of the Telegram API
+type redirectTransport struct {
+	target    *url.URL
+	transport http.RoundTripper
+}
+
+func (rt *redirectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = rt.target.Scheme
+	req.URL.Host = rt.target.Host
+	return rt.transport.RoundTrip(req)
+}
+
+// newTestServer starts a fake Telegram API server and returns a client that talks to it.
+// If connState is non-nil, it is installed as the server's ConnState hook.
+func newTestServer(t *testing.T, handler http.HandlerFunc, connState func(net.Conn, http.ConnState)) *http.Client {
+	server := httptest.NewUnstartedServer(handler)
+	server.Config.ConnState = connState
+	server.Start()
+	t.Cleanup(server.Close)
+
+	target, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatalf("Failed to parse test server URL: %v", err)
+	}
+	transport := &http.Transport{}
+	t.Cleanup(transport.CloseIdleConnections)
+
+	client := &http.Client{
+		Transport: &redirectTransport{target: target, transport: transport},
+	}
+	return client
+}
+
 func skipIfNoLogger(t *testing.T) {
 	if testLogger == nil {
 		t.Skip("Skipping test: TELEGRAM_BOT_TOKEN and TELEGRAM_CHAT_ID environment variables are required")
@@ -145,3 +193,661 @@ func TestLog(t *testing.T) {
 		t.Errorf("Log failed: %v", err)
 	}
 }
+
+func TestAnnounce(t *testing.T) {
+	skipIfNoLogger(t)
+	messageID, err := testLogger.Announce(context.Background(), "Test announcement")
+	if err != nil {
+		var apiErr *telelogger.APIError
+		if errors.As(err, &apiErr) && messageID != 0 {
+			t.Skipf("Announce sent message %d but could not pin it: %v", messageID, err)
+		}
+		t.Fatalf("Announce failed: %v", err)
+	}
+	if messageID == 0 {
+		t.Error("Announce should return the ID of the sent message")
+	}
+}
+
+func TestAnnounceOffline(t *testing.T) {
+	for _, pinOK := range []bool{true, false} {
+		var pinBody atomic.Value
+		client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+			switch {
+			case strings.HasSuffix(r.URL.Path, "/sendMessage"):
+				w.Write([]byte(`{"ok":true,"result":{"message_id":7}}`))
+			case strings.HasSuffix(r.URL.Path, "/pinChatMessage"):
+				body, _ := io.ReadAll(r.Body)
+				pinBody.Store(string(body))
+				if pinOK {
+					w.Write([]byte(`{"ok":true,"result":true}`))
+					return
+				}
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: not enough rights to manage pinned messages in the chat"}`))
+			default:
+				http.NotFound(w, r)
+			}
+		}, nil)
+
+		logger := telelogger.New(telelogger.Config{
+			BotToken:   "test-token",
+			ChatID:     123456789,
+			HTTPClient: client,
+		})
+
+		messageID, err := logger.Announce(context.Background(), "Test announcement")
+		if messageID != 7 {
+			t.Errorf("Announce should return the ID of the sent message, got %d", messageID)
+		}
+
+		body, _ := pinBody.Load().(string)
+		if !strings.Contains(body, `"message_id":7`) || !strings.Contains(body, `"disable_notification":false`) {
+			t.Errorf("Announce should pin the sent message loudly, got: %s", body)
+		}
+
+		var apiErr *telelogger.APIError
+		if pinOK && err != nil {
+			t.Errorf("Announce failed: %v", err)
+		}
+		if !pinOK && (!errors.As(err, &apiErr) || !strings.Contains(apiErr.Description, "not enough rights")) {
+			t.Errorf("Announce should return an *APIError when pinning fails, got: %v", err)
+		}
+	}
+}
+
+func TestBlockPatterns(t *testing.T) {
+	var fallback bytes.Buffer
+	logger := telelogger.New(telelogger.Config{
+		BotToken:       "test-token",
+		ChatID:         123456789,
+		BlockPatterns:  []*regexp.Regexp{regexp.MustCompile(`\b\d{4}-\d{4}-\d{4}-\d{4}\b`)},
+		FallbackWriter: &fallback,
+	})
+
+	err := logger.LogError("payment failed for card 4111-1111-1111-1111")
+	if !errors.Is(err, telelogger.ErrBlocked) {
+		t.Fatalf("LogError should return ErrBlocked, got: %v", err)
+	}
+	if !strings.Contains(fallback.String(), "4111-1111-1111-1111") {
+		t.Errorf("blocked message should be written to the fallback writer, got: %q", fallback.String())
+	}
+}
+
+func TestResolverFailure(t *testing.T) {
+	dnsErr := errors.New("dns unavailable")
+	logger := telelogger.New(telelogger.Config{
+		BotToken:    "test-token",
+		ChatID:      123456789,
+		DialTimeout: time.Second,
+		Resolver: &net.Resolver{
+			PreferGo: true,
+			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+				return nil, dnsErr
+			},
+		},
+	})
+
+	start := time.Now()
+	if err := logger.LogInfo("Test message"); err == nil {
+		t.Fatal("LogInfo should fail when the resolver cannot resolve the API host")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("LogInfo should fail fast on resolver errors, took %v", elapsed)
+	}
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) { return f(req) }
+
+func TestDialTimeoutWithWrappedDefaultTransport(t *testing.T) {
+	original := http.DefaultTransport
+	t.Cleanup(func() { http.DefaultTransport = original })
+	http.DefaultTransport = roundTripperFunc(original.RoundTrip)
+
+	logger := telelogger.New(telelogger.Config{
+		BotToken:    "test-token",
+		ChatID:      123456789,
+		DialTimeout: time.Second,
+	})
+
+	if logger == nil {
+		t.Error("New() should return a non-nil logger when http.DefaultTransport is wrapped")
+	}
+}
+
+func TestDialTimeout(t *testing.T) {
+	const dialTimeout = 200 * time.Millisecond
+	logger := telelogger.New(telelogger.Config{
+		BotToken:    "test-token",
+		ChatID:      123456789,
+		DialTimeout: dialTimeout,
+		Resolver: &net.Resolver{
+			PreferGo: true,
+			// Simulate a DNS server that never answers
+			Dial: func(ctx context.Context, network, address string) (net.Conn, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		},
+	})
+
+	start := time.Now()
+	if err := logger.LogInfo("Test message"); err == nil {
+		t.Fatal("LogInfo should fail when name resolution hangs")
+	}
+	if elapsed := time.Since(start); elapsed < dialTimeout || elapsed > dialTimeout+time.Second {
+		t.Errorf("LogInfo should fail after about the dial timeout (%v), took %v", dialTimeout, elapsed)
+	}
+}
+
+func TestDeleteMessages(t *testing.T) {
+	var singles int32
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/deleteMessages"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"ok":false,"error_code":404,"description":"Not Found"}`))
+		case strings.HasSuffix(r.URL.Path, "/deleteMessage"):
+			atomic.AddInt32(&singles, 1)
+			if strings.Contains(string(body), `"message_id":102`) {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: message to delete not found"}`))
+				return
+			}
+			w.Write([]byte(`{"ok":true,"result":true}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}, nil)
+
+	logger := telelogger.New(telelogger.Config{
+		BotToken:   "test-token",
+		ChatID:     123456789,
+		HTTPClient: client,
+	})
+
+	err := logger.DeleteMessages(context.Background(), []int{101, 102, 103})
+
+	var delErr *telelogger.DeleteMessagesError
+	if !errors.As(err, &delErr) {
+		t.Fatalf("DeleteMessages should return a *DeleteMessagesError, got: %v", err)
+	}
+	if len(delErr.Failed) != 1 || delErr.Failed[102] == nil {
+		t.Errorf("only message 102 should be reported as failed, got: %v", delErr.Failed)
+	}
+	if n := atomic.LoadInt32(&singles); n != 3 {
+		t.Errorf("DeleteMessages should fall back to deleting each message, made %d calls", n)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err = logger.DeleteMessages(ctx, []int{101, 102, 103})
+	if !errors.Is(err, context.Canceled) || !errors.As(err, &delErr) || len(delErr.Failed) != 3 {
+		t.Errorf("DeleteMessages should report unprocessed messages with the context error, got: %v", err)
+	}
+}
+
+func TestDeleteMessagesKeepsPartialResults(t *testing.T) {
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/deleteMessages"):
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request"}`))
+		case strings.Contains(string(body), `"message_id":102`):
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: message to delete not found"}`))
+		case strings.Contains(string(body), `"message_id":103`):
+			// Drop the connection to simulate a network failure
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+		default:
+			w.Write([]byte(`{"ok":true,"result":true}`))
+		}
+	}, nil)
+
+	logger := telelogger.New(telelogger.Config{
+		BotToken:   "test-token",
+		ChatID:     123456789,
+		HTTPClient: client,
+	})
+
+	err := logger.DeleteMessages(context.Background(), []int{101, 102, 103, 104})
+
+	var delErr *telelogger.DeleteMessagesError
+	if !errors.As(err, &delErr) {
+		t.Fatalf("DeleteMessages should return a *DeleteMessagesError, got: %v", err)
+	}
+	var apiErr *telelogger.APIError
+	if !errors.As(delErr.Failed[102], &apiErr) {
+		t.Errorf("message 102 should keep its API error, got: %v", delErr.Failed[102])
+	}
+	if delErr.Failed[103] == nil || delErr.Failed[104] == nil {
+		t.Errorf("messages 103 and 104 should be reported as unprocessed, got: %v", delErr.Failed)
+	}
+	if _, ok := delErr.Failed[101]; ok || len(delErr.Failed) != 3 {
+		t.Errorf("only messages 102-104 should be reported as failed, got: %v", delErr.Failed)
+	}
+}
+
+// newSendMessageServer starts a fake Telegram API server that, like Telegram,
+// rejects texts over 4096 characters. It returns a client that talks to it and a
+// function returning the text of the last sent message.
+func newSendMessageServer(t *testing.T) (*http.Client, func() string) {
+	var last atomic.Value
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Text string `json:"text"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Text) > 4096 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: message is too long"}`))
+			return
+		}
+		last.Store(req.Text)
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}, nil)
+
+	return client, func() string {
+		text, _ := last.Load().(string)
+		return text
+	}
+}
+
+func TestCaptureStackOnError(t *testing.T) {
+	for _, stackSkip := range []int{0, -1} {
+		client, lastText := newSendMessageServer(t)
+		logger := telelogger.New(telelogger.Config{
+			BotToken:            "test-token",
+			ChatID:              123456789,
+			HTTPClient:          client,
+			CaptureStackOnError: true,
+			StackSkip:           stackSkip,
+		})
+
+		if err := logger.LogError("Test error message"); err != nil {
+			t.Fatalf("LogError failed: %v", err)
+		}
+
+		lines := strings.Split(lastText(), "\n")
+		if len(lines) < 4 || !strings.HasSuffix(lines[3], "TestCaptureStackOnError") {
+			t.Errorf("stack trace with StackSkip %d should start at the caller of LogError, got:\n%s", stackSkip, lastText())
+		}
+	}
+}
+
+func TestCaptureStackOnErrorDoesNotFormatPerFrame(t *testing.T) {
+	client, _ := newSendMessageServer(t)
+	var calls int
+	logger := telelogger.New(telelogger.Config{
+		BotToken:            "test-token",
+		ChatID:              123456789,
+		HTTPClient:          client,
+		CaptureStackOnError: true,
+		ErrorFormatter: func(msg string) string {
+			calls++
+			return "Custom:" + msg
+		},
+	})
+
+	if err := logger.LogError(strings.Repeat("x", 3900)); err != nil {
+		t.Fatalf("LogError failed: %v", err)
+	}
+
+	if calls > 2 {
+		t.Errorf("ErrorFormatter should be called at most twice (to measure and to format), not per frame; called %d times", calls)
+	}
+}
+
+func TestCaptureStackOnErrorEscapingFormatter(t *testing.T) {
+	client, lastText := newSendMessageServer(t)
+	logger := telelogger.New(telelogger.Config{
+		BotToken:            "test-token",
+		ChatID:              123456789,
+		HTTPClient:          client,
+		ParseMode:           telelogger.ParseModeHTML,
+		CaptureStackOnError: true,
+		ErrorFormatter: func(msg string) string {
+			return "<b>Error:</b>\n" + html.EscapeString(msg)
+		},
+	})
+
+	// Every "<" grows to "&lt;" when escaped
+	if err := logger.LogError(strings.Repeat("<", 3000)); err != nil {
+		t.Fatalf("LogError with an escaping formatter failed: %v", err)
+	}
+	if len(lastText()) > 4096 {
+		t.Errorf("formatted message should fit Telegram's limit, got %d bytes", len(lastText()))
+	}
+}
+
+func TestSharedHTTPClient(t *testing.T) {
+	var connections int32
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}` + "\n"))
+	}, func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	})
+
+	var loggers []*telelogger.Telelogger
+	for i := 0; i < 10; i++ {
+		loggers = append(loggers, telelogger.New(telelogger.Config{
+			BotToken:   "test-token",
+			ChatID:     int64(i),
+			HTTPClient: client,
+		}))
+	}
+
+	for _, logger := range loggers {
+		if err := logger.LogInfo("Test info message"); err != nil {
+			t.Fatalf("LogInfo failed: %v", err)
+		}
+	}
+
+	if n := atomic.LoadInt32(&connections); n != 1 {
+		t.Errorf("loggers sharing a client should reuse one connection, opened %d", n)
+	}
+
+	// Loggers sharing a client may be used concurrently
+	var wg sync.WaitGroup
+	errs := make(chan error, len(loggers)*5)
+	for _, logger := range loggers {
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func(logger *telelogger.Telelogger) {
+				defer wg.Done()
+				errs <- logger.LogInfo("Test info message")
+			}(logger)
+		}
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("concurrent LogInfo failed: %v", err)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	tests := map[time.Duration]string{
+		0:                                  "0ns",
+		750 * time.Nanosecond:              "750ns",
+		1500 * time.Microsecond:            "1.5ms",
+		1234567890 * time.Nanosecond:       "1.23s",
+		2 * time.Second:                    "2s",
+		90 * time.Second:                   "1m30s",
+		-250 * time.Millisecond:            "-250ms",
+		999999 * time.Nanosecond:           "1ms",
+		999999999 * time.Nanosecond:        "1s",
+		59999 * time.Millisecond:           "1m0s",
+		3*time.Hour + 400*time.Millisecond: "3h0m0s",
+	}
+	for d, want := range tests {
+		if got := telelogger.FormatDuration(d); got != want {
+			t.Errorf("FormatDuration(%d) = %q, want %q", int64(d), got, want)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		0:       "0 B",
+		512:     "512 B",
+		1536:    "1.5 KiB",
+		1048576: "1.0 MiB",
+		5 << 30: "5.0 GiB",
+		-2048:   "-2.0 KiB",
+		1048575: "1.0 MiB",
+		1023:    "1023 B",
+	}
+	for n, want := range tests {
+		if got := telelogger.FormatBytes(n); got != want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+// editServer is a fake Telegram API server for the edit dedup strategy. Like
+// Telegram, it rejects texts over 4096 characters.
+type editServer struct {
+	sends    int32
+	edits    int32
+	lastEdit atomic.Value
+}
+
+func newEditServer(t *testing.T) (*editServer, *http.Client) {
+	server := &editServer{}
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Text string `json:"text"`
+		}
+		json.NewDecoder(r.Body).Decode(&req)
+		if len(req.Text) > 4096 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: message is too long"}`))
+			return
+		}
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/sendMessage"):
+			atomic.AddInt32(&server.sends, 1)
+		case strings.HasSuffix(r.URL.Path, "/editMessageText"):
+			atomic.AddInt32(&server.edits, 1)
+			server.lastEdit.Store(req.Text)
+		}
+		w.Write([]byte(`{"ok":true,"result":{"message_id":42}}`))
+	}, nil)
+	return server, client
+}
+
+// waitForEdit waits until the last edited text contains want and returns it
+func (s *editServer) waitForEdit(want string) string {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		edit, _ := s.lastEdit.Load().(string)
+		if strings.Contains(edit, want) || time.Now().After(deadline) {
+			return edit
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDedupStrategyEdit(t *testing.T) {
+	server, client := newEditServer(t)
+	logger := telelogger.New(telelogger.Config{
+		BotToken:      "test-token",
+		ChatID:        123456789,
+		HTTPClient:    client,
+		DedupStrategy: telelogger.DedupStrategyEdit,
+	})
+
+	for i := 0; i < 3; i++ {
+		if err := logger.LogError("DB timeout"); err != nil {
+			t.Fatalf("LogError failed: %v", err)
+		}
+	}
+	if err := logger.LogError("Cache miss storm"); err != nil {
+		t.Fatalf("LogError failed: %v", err)
+	}
+
+	if edit := server.waitForEdit("seen 3×"); !strings.HasPrefix(edit, "❌ Error:\nDB timeout") || !strings.Contains(edit, "seen 3×") {
+		t.Errorf("edit should update the first message with the count, got: %s", edit)
+	}
+	if n := atomic.LoadInt32(&server.sends); n != 2 {
+		t.Errorf("expected 2 messages to be sent, got %d", n)
+	}
+}
+
+func TestDedupStrategyEditNearLimit(t *testing.T) {
+	server, client := newEditServer(t)
+	logger := telelogger.New(telelogger.Config{
+		BotToken:      "test-token",
+		ChatID:        123456789,
+		HTTPClient:    client,
+		DedupStrategy: telelogger.DedupStrategyEdit,
+	})
+
+	msg := strings.Repeat("x", 4090)
+	for i := 0; i < 3; i++ {
+		if err := logger.LogError(msg); err != nil {
+			t.Fatalf("LogError failed: %v", err)
+		}
+	}
+
+	if edit := server.waitForEdit("seen 3×"); !strings.Contains(edit, "seen 3×") {
+		t.Errorf("edit of a long error should show the count, got %d bytes ending in: %q", len(edit), edit[max(0, len(edit)-40):])
+	}
+	if n := atomic.LoadInt32(&server.sends); n != 1 {
+		t.Errorf("repeats of a long error should not send new messages, sent %d", n)
+	}
+}
+
+func TestDedupStrategyEditConcurrentRepeats(t *testing.T) {
+	server, client := newEditServer(t)
+	logger := telelogger.New(telelogger.Config{
+		BotToken:      "test-token",
+		ChatID:        123456789,
+		HTTPClient:    client,
+		DedupStrategy: telelogger.DedupStrategyEdit,
+	})
+
+	const repeats = 50
+	var wg sync.WaitGroup
+	for i := 0; i < repeats; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := logger.LogError("DB timeout"); err != nil {
+				t.Errorf("LogError failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if edit := server.waitForEdit("seen 50×"); !strings.Contains(edit, "seen 50×") {
+		t.Errorf("the final edit should show every repeat, got: %s", edit)
+	}
+	if n := atomic.LoadInt32(&server.sends); n != 1 {
+		t.Errorf("expected 1 message to be sent, got %d", n)
+	}
+	if n := atomic.LoadInt32(&server.edits); n > 3 {
+		t.Errorf("edits should be coalesced, got %d edits for %d repeats", n, repeats)
+	}
+}
+
+func TestDedupStrategyEditDoesNotBlock(t *testing.T) {
+	release := make(chan struct{})
+	client := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if strings.Contains(string(body), "slow error") {
+			<-release
+		}
+		w.Write([]byte(`{"ok":true,"result":{"message_id":42}}`))
+	}, nil)
+	defer close(release)
+
+	logger := telelogger.New(telelogger.Config{
+		BotToken:      "test-token",
+		ChatID:        123456789,
+		HTTPClient:    client,
+		DedupStrategy: telelogger.DedupStrategyEdit,
+	})
+
+	go logger.LogError("slow error")
+	time.Sleep(50 * time.Millisecond)
+
+	done := make(chan error, 1)
+	go func() { done <- logger.LogError("fast error") }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("LogError failed: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Error("LogError should not wait for an in-flight request for a different error")
+	}
+}
+
+// recordingLogger is a Logger that records the messages it receives
+type recordingLogger struct {
+	messages []string
+	err      error
+}
+
+func (l *recordingLogger) record(msg string) error {
+	l.messages = append(l.messages, msg)
+	return l.err
+}
+
+func (l *recordingLogger) Log(msg string) error { return l.record(msg) }
+func (l *recordingLogger) LogWithParseMode(msg string, _ telelogger.ParseMode) error {
+	return l.record(msg)
+}
+func (l *recordingLogger) LogError(err interface{}) error {
+	if e, ok := err.(error); ok {
+		return l.record(e.Error())
+	}
+	return l.record(err.(string))
+}
+func (l *recordingLogger) LogInfo(msg string) error    { return l.record(msg) }
+func (l *recordingLogger) LogSuccess(msg string) error { return l.record(msg) }
+func (l *recordingLogger) LogWarn(msg string) error    { return l.record(msg) }
+
+func TestNewTee(t *testing.T) {
+	failErr := errors.New("backend down")
+	first := &recordingLogger{err: failErr}
+	second := &recordingLogger{}
+
+	logger := telelogger.NewTee(first, second)
+
+	if err := logger.LogInfo("Test info message"); !errors.Is(err, failErr) {
+		t.Errorf("LogInfo should return the error of the failing logger, got: %v", err)
+	}
+	if err := logger.LogError(errors.New("test error")); !errors.Is(err, failErr) {
+		t.Errorf("LogError should return the error of the failing logger, got: %v", err)
+	}
+
+	for _, l := range []*recordingLogger{first, second} {
+		if len(l.messages) != 2 || l.messages[0] != "Test info message" || l.messages[1] != "test error" {
+			t.Errorf("every logger should receive every message, got: %q", l.messages)
+		}
+	}
+
+	if err := telelogger.NewTee(second).LogWarn("Test warning message"); err != nil {
+		t.Errorf("LogWarn should succeed when all loggers succeed, got: %v", err)
+	}
+}
+
+func TestNewTeeCaptureStackOnError(t *testing.T) {
+	for _, stackSkip := range []int{0, -1} {
+		client, lastText := newSendMessageServer(t)
+		telegram := telelogger.New(telelogger.Config{
+			BotToken:            "test-token",
+			ChatID:              123456789,
+			HTTPClient:          client,
+			CaptureStackOnError: true,
+			StackSkip:           stackSkip,
+		})
+		recorder := &recordingLogger{}
+
+		logger := telelogger.NewTee(recorder, telelogger.NewTee(telegram))
+		if err := logger.LogError("Test error message"); err != nil {
+			t.Fatalf("LogError through a tee failed: %v", err)
+		}
+
+		lines := strings.Split(lastText(), "\n")
+		if len(lines) < 4 || !strings.HasSuffix(lines[3], "TestNewTeeCaptureStackOnError") {
+			t.Errorf("stack trace with StackSkip %d should start at the caller of the tee, got:\n%s", stackSkip, lastText())
+		}
+		if len(recorder.messages) != 1 {
+			t.Errorf("loggers without stack capture should still receive the error, got: %q", recorder.messages)
+		}
+	}
+}